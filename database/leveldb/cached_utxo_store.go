@@ -0,0 +1,117 @@
+package leveldb
+
+import (
+	"sync"
+	"time"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/bytom/database/storage"
+	"github.com/bytom/protocol/bc"
+)
+
+// CachedUtxoStore wraps the on-disk UTXO store with a write-back memory
+// cache, the same MemCachedStore pattern neo-go's Blockchain uses over its
+// persistent store: UTXO mutations staged through SaveUtxoView accumulate
+// in the cache and only reach LevelDB when the cache is flushed, either
+// explicitly via Persist, or from within SaveUtxoView once dirty entries
+// reach flushThreshold or persistInterval has elapsed since the last flush.
+// There is no background timer: an idle cache is not flushed until the
+// next SaveUtxoView or Persist call.
+//
+// SCOPE CUT: this type is standalone and is not wired into protocol.Chain.
+// SolveAndUpdate still writes every block's UTXO set straight to LevelDB;
+// nothing in the real block-application path reads or writes through this
+// cache. Today the only caller is the test harness in package test, which
+// populates it itself (ChainTestContext.applyBlockToCache) purely to
+// exercise the cache's own read/write/flush behavior. Making SolveAndUpdate
+// actually go through a CachedUtxoStore requires changes to protocol.Chain,
+// which isn't part of this change.
+type CachedUtxoStore struct {
+	db              dbm.DB
+	persistInterval time.Duration
+	flushThreshold  int
+
+	mu        sync.Mutex
+	dirty     map[bc.Hash]*storage.UtxoEntry // a nil value marks the key for deletion
+	lastFlush time.Time
+}
+
+// NewCachedUtxoStore wraps db with a write-back memory cache that flushes
+// once flushThreshold entries are dirty or persistInterval has elapsed,
+// whichever comes first; both triggers are checked only when SaveUtxoView
+// is called. A zero flushThreshold disables the size trigger.
+func NewCachedUtxoStore(db dbm.DB, persistInterval time.Duration, flushThreshold int) *CachedUtxoStore {
+	return &CachedUtxoStore{
+		db:              db,
+		persistInterval: persistInterval,
+		flushThreshold:  flushThreshold,
+		dirty:           make(map[bc.Hash]*storage.UtxoEntry),
+		lastFlush:       time.Now(),
+	}
+}
+
+// GetUtxo returns the UTXO entry for hash, preferring the write cache over
+// LevelDB so reads observe mutations - including deletions staged but not
+// yet persisted - before they reach LevelDB.
+func (c *CachedUtxoStore) GetUtxo(hash *bc.Hash) (*storage.UtxoEntry, error) {
+	c.mu.Lock()
+	entry, ok := c.dirty[*hash]
+	c.mu.Unlock()
+	if ok {
+		return entry, nil
+	}
+	return GetUtxo(c.db, hash)
+}
+
+// SaveUtxoView stages every entry in view in the write cache; a nil value
+// marks that key for deletion. Nothing reaches LevelDB until Persist is
+// called, flushThreshold dirty entries accumulate, or persistInterval
+// elapses.
+func (c *CachedUtxoStore) SaveUtxoView(view map[bc.Hash]*storage.UtxoEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for hash, entry := range view {
+		c.dirty[hash] = entry
+	}
+
+	if (c.flushThreshold > 0 && len(c.dirty) >= c.flushThreshold) || time.Since(c.lastFlush) >= c.persistInterval {
+		return c.persist()
+	}
+	return nil
+}
+
+// Persist flushes every dirty UTXO entry to LevelDB through the package's
+// own SaveUtxoView, so cached writes land in exactly the same on-disk
+// encoding uncached callers already use instead of a second, independently
+// maintained key/value format.
+func (c *CachedUtxoStore) Persist() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.persist()
+}
+
+func (c *CachedUtxoStore) persist() error {
+	c.lastFlush = time.Now()
+	if len(c.dirty) == 0 {
+		return nil
+	}
+
+	batch := c.db.NewBatch()
+	if err := SaveUtxoView(batch, c.dirty); err != nil {
+		return err
+	}
+	batch.Write()
+
+	c.dirty = make(map[bc.Hash]*storage.UtxoEntry)
+	return nil
+}
+
+// Discard drops every staged UTXO mutation without writing to LevelDB, for
+// when a branch is abandoned before its dirty entries are ever persisted.
+func (c *CachedUtxoStore) Discard() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dirty = make(map[bc.Hash]*storage.UtxoEntry)
+}
@@ -0,0 +1,71 @@
+package test
+
+import (
+	"encoding/binary"
+	"os"
+	"testing"
+	"time"
+
+	dbm "github.com/tendermint/tmlibs/db"
+
+	"github.com/bytom/database/leveldb"
+	"github.com/bytom/database/storage"
+	"github.com/bytom/protocol/bc"
+)
+
+const benchUtxoCount = 100
+
+// benchUtxoViews builds n single-entry UTXO views with distinct hashes, so
+// each SaveUtxoView call below stages a genuinely new dirty entry instead
+// of overwriting the same key.
+func benchUtxoViews(n int) []map[bc.Hash]*storage.UtxoEntry {
+	views := make([]map[bc.Hash]*storage.UtxoEntry, n)
+	for i := 0; i < n; i++ {
+		var raw [32]byte
+		binary.BigEndian.PutUint64(raw[:8], uint64(i))
+		hash := bc.NewHash(raw)
+		views[i] = map[bc.Hash]*storage.UtxoEntry{hash: {BlockHeight: uint64(i)}}
+	}
+	return views
+}
+
+// benchmarkCachedUtxoStore drives benchUtxoCount UTXO writes directly
+// through a CachedUtxoStore built by newCache, isolating what the cache
+// itself costs. This does not exercise SolveAndUpdate/protocol.Chain -
+// CachedUtxoStore isn't wired into the real block-application path yet -
+// so it compares batched vs. per-write flushing of the cache in isolation,
+// not the cost of applying a block with or without it.
+func benchmarkCachedUtxoStore(b *testing.B, newCache func(dbm.DB) *leveldb.CachedUtxoStore) {
+	db := dbm.NewDB("cached_utxo_bench_db", "leveldb", "cached_utxo_bench_db")
+	defer os.RemoveAll("cached_utxo_bench_db")
+	views := benchUtxoViews(benchUtxoCount)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache := newCache(db)
+		for _, view := range views {
+			if err := cache.SaveUtxoView(view); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := cache.Persist(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkCachedUtxoStoreBatched flushes only once, after all
+// benchUtxoCount writes have accumulated in memory.
+func BenchmarkCachedUtxoStoreBatched(b *testing.B) {
+	benchmarkCachedUtxoStore(b, func(db dbm.DB) *leveldb.CachedUtxoStore {
+		return leveldb.NewCachedUtxoStore(db, time.Hour, 0)
+	})
+}
+
+// BenchmarkCachedUtxoStoreUnbatched flushes to LevelDB after every write,
+// the per-output round trip CachedUtxoStore otherwise avoids.
+func BenchmarkCachedUtxoStoreUnbatched(b *testing.B) {
+	benchmarkCachedUtxoStore(b, func(db dbm.DB) *leveldb.CachedUtxoStore {
+		return leveldb.NewCachedUtxoStore(db, 0, 1)
+	})
+}
@@ -20,27 +20,97 @@ import (
 
 const utxoPrefix = "UT:"
 
+// defaultCachePersistInterval/defaultCacheFlushThreshold size the write-back
+// cache each test chain is built with; scenarios that need a flush at a
+// specific height use ChainTestConfig.CacheFlushAt instead of waiting on
+// either of these.
+const (
+	defaultCachePersistInterval = 3 * time.Second
+	defaultCacheFlushThreshold  = 128
+)
+
+// reorganizeCatchUpCap bounds how many empty blocks ctFork.catchUp will
+// mine while trying to out-weigh the main chain, so a scenario that can
+// never actually win fails fast instead of looping forever.
+const reorganizeCatchUpCap = 10000
+
 type ChainTestContext struct {
 	Chain *protocol.Chain
 	DB    dbm.DB
+
+	// Cache is a write-back layer over the UTXO entries Chain persists to
+	// DB; validateExecution reads through it so tests exercise both the
+	// cached and the flushed state. validateRollback does not consult it:
+	// it compares the entries getUtxoEntries reads from DB. SCOPE CUT: Chain's
+	// own writes (SolveAndUpdate) still go straight to DB - Cache is kept
+	// in sync by applyBlockToCache below, not by protocol.Chain itself.
+	Cache *leveldb.CachedUtxoStore
+
+	// Clock scripts block timestamps instead of relying on wall-clock time
+	// elapsing between blocks.
+	Clock *TestClock
+
+	// Solver solves and submits each block; scenarios that need long
+	// chains install a mockSolver via ChainTestConfig.MockDifficulty.
+	Solver Solver
 }
 
 func (ctx *ChainTestContext) append(blkNum uint64) error {
 	for i := uint64(0); i < blkNum; i++ {
 		prevBlock := ctx.Chain.BestBlock()
-		timestamp := prevBlock.Timestamp + defaultDuration
+		timestamp := ctx.Clock.Advance(defaultDuration)
 		prevBlockHash := prevBlock.Hash()
 		block, err := DefaultEmptyBlock(prevBlock.Height+1, timestamp, prevBlockHash, prevBlock.Bits)
 		if err != nil {
 			return err
 		}
-		if err := SolveAndUpdate(ctx.Chain, block); err != nil {
+		if err := ctx.Solver.Solve(ctx, block); err != nil {
 			return nil
 		}
+		if err := ctx.applyBlockToCache(block); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// applyBlockToCache stages block's UTXO mutations in ctx.Cache, mirroring
+// what SolveAndUpdate already wrote through to LevelDB so cached reads and
+// flushed reads agree on the same state: ordinary spent outputs disappear
+// entirely, just like validateExecution already expects from LevelDB, and
+// only coinbase outputs stick around marked Spent.
+func (ctx *ChainTestContext) applyBlockToCache(block *types.Block) error {
+	view := make(map[bc.Hash]*storage.UtxoEntry, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		// the block's coinbase is always Transactions[0]; every result
+		// it produces has to keep IsCoinBase set so a later spend keeps
+		// it marked Spent, as LevelDB does, instead of dropping it.
+		isCoinBase := i == 0
+
+		for _, spentOutputID := range tx.SpentOutputIDs {
+			entry, err := ctx.Cache.GetUtxo(&spentOutputID)
+			if err != nil || entry == nil {
+				continue
+			}
+			if !entry.IsCoinBase {
+				view[spentOutputID] = nil // tombstone: delete on persist
+				continue
+			}
+			spentEntry := *entry
+			spentEntry.Spent = true
+			view[spentOutputID] = &spentEntry
+		}
+
+		for _, outputID := range tx.ResultIds {
+			if isSpent(outputID, block) {
+				continue
+			}
+			view[*outputID] = &storage.UtxoEntry{BlockHeight: block.Height, IsCoinBase: isCoinBase}
+		}
+	}
+	return ctx.Cache.SaveUtxoView(view)
+}
+
 func (ctx *ChainTestContext) validateStatus(block *types.Block) error {
 	// validate in mainchain
 	if !ctx.Chain.InMainChain(block.Height, block.Hash()) {
@@ -79,7 +149,7 @@ func (ctx *ChainTestContext) validateStatus(block *types.Block) error {
 func (ctx *ChainTestContext) validateExecution(block *types.Block) error {
 	for _, tx := range block.Transactions {
 		for _, spentOutputID := range tx.SpentOutputIDs {
-			utxoEntry, _ := leveldb.GetUtxo(ctx.DB, &spentOutputID)
+			utxoEntry, _ := ctx.Cache.GetUtxo(&spentOutputID)
 			if utxoEntry == nil {
 				continue
 			}
@@ -92,7 +162,7 @@ func (ctx *ChainTestContext) validateExecution(block *types.Block) error {
 		}
 
 		for _, outputID := range tx.ResultIds {
-			utxoEntry, _ := leveldb.GetUtxo(ctx.DB, outputID)
+			utxoEntry, _ := ctx.Cache.GetUtxo(outputID)
 			if utxoEntry == nil && isSpent(outputID, block) {
 				continue
 			}
@@ -142,11 +212,54 @@ func (ctx *ChainTestContext) validateRollback(utxoEntries map[string]*storage.Ut
 type ChainTestConfig struct {
 	RollbackTo uint64     `json:"rollback_to"`
 	Blocks     []*ctBlock `json:"blocks"`
+
+	// Forks declares competing branches that split off the canonical
+	// chain built from Blocks. Each fork keeps its own tip until fed via
+	// ForkOrder, so scenarios can script reorgs instead of only straight
+	// rollbacks.
+	Forks []*ctFork `json:"forks"`
+
+	// ForkOrder interleaves block submission across forks: entry i names
+	// the fork (by ctFork.Name) whose next pending block is solved and
+	// fed to SolveAndUpdate at step i.
+	ForkOrder []string `json:"fork_order"`
+
+	// WantMainChain names the fork expected to win chain selection once
+	// ForkOrder has been drained. Empty means the canonical chain (Blocks)
+	// is expected to stay on top.
+	WantMainChain string `json:"want_main_chain"`
+
+	// CacheFlushAt lists heights of blocks scripted in Blocks at which
+	// ctx.Cache is forced to Persist, so a scenario can assert against both
+	// the cached and the flushed-to-LevelDB state. Heights reached through
+	// Append or on a fork never trigger a flush.
+	CacheFlushAt []uint64 `json:"cache_flush_at"`
+
+	// MockDifficulty, when set, installs a mockSolver that eases every
+	// block's target to this value instead of mining under the real one,
+	// so a scenario can script hundreds of blocks cheaply.
+	MockDifficulty uint64 `json:"mock_difficulty"`
 }
 
 type ctBlock struct {
 	Transactions []*ctTransaction `json:"transactions"`
 	Append       uint64           `json:"append"`
+
+	// Timestamp and Bits override this block's header on both the
+	// canonical and fork paths; zero leaves whatever the path would have
+	// produced anyway (NewBlock's own timestamp/bits on the canonical
+	// path, or TestClock/the fork's target on a fork). When
+	// ChainTestConfig.MockDifficulty is set, the mock solver replaces Bits
+	// with that value before solving, so a Bits override does not reach
+	// the solved header.
+	Timestamp uint64 `json:"timestamp"`
+	Bits      uint64 `json:"bits"`
+
+	// Reorganize marks this as the fork's last scripted block: after it
+	// is solved, the harness keeps mining empty blocks on the same fork
+	// (advancing Clock each time) until the fork out-weighs the current
+	// tip and Chain.ProcessBlock switches the main chain over to it.
+	Reorganize bool `json:"reorganize"`
 }
 
 func (b *ctBlock) createBlock(ctx *ChainTestContext) (*types.Block, error) {
@@ -158,7 +271,184 @@ func (b *ctBlock) createBlock(ctx *ChainTestContext) (*types.Block, error) {
 		}
 		txs = append(txs, tx)
 	}
-	return NewBlock(ctx.Chain, txs, []byte{byte(vm.OP_TRUE)})
+
+	block, err := NewBlock(ctx.Chain, txs, []byte{byte(vm.OP_TRUE)})
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamp/Bits don't factor into the transaction commitments NewBlock
+	// already computed, so overriding them here is safe on the canonical
+	// path too, not just for forks.
+	if b.Timestamp != 0 {
+		block.Timestamp = b.Timestamp
+	}
+	if b.Bits != 0 {
+		block.Bits = b.Bits
+	}
+	return block, nil
+}
+
+// ctFork is a competing branch that splits off the canonical chain at
+// ParentHeight. Its blocks are built and solved independently of
+// ctx.Chain's current tip, so a fork can keep losing the chain-selection
+// race for a while before (or without ever) overtaking it.
+type ctFork struct {
+	Name         string     `json:"name"`
+	ParentHeight uint64     `json:"parent_height"`
+	Bits         uint64     `json:"bits"`
+	Blocks       []*ctBlock `json:"blocks"`
+
+	// solved holds the blocks already produced for this fork, in order,
+	// so later blocks in the same fork can chain off them instead of off
+	// the canonical chain.
+	solved []*types.Block
+}
+
+// parentBlock returns the block a fork's next block should build on: its
+// own last solved block, or the shared ancestor at ParentHeight.
+func (f *ctFork) parentBlock(ctx *ChainTestContext) (*types.Block, error) {
+	if n := len(f.solved); n > 0 {
+		return f.solved[n-1], nil
+	}
+	return ctx.Chain.GetBlockByHeight(f.ParentHeight)
+}
+
+// blockAt resolves the block standing at height on this fork's branch:
+// ctx.Chain's block for heights at or below the fork point, otherwise the
+// fork's own locally solved block.
+func (f *ctFork) blockAt(ctx *ChainTestContext, height uint64) (*types.Block, error) {
+	if height <= f.ParentHeight {
+		return ctx.Chain.GetBlockByHeight(height)
+	}
+	idx := height - f.ParentHeight - 1
+	if idx >= uint64(len(f.solved)) {
+		return nil, fmt.Errorf("fork %q has no block at height %d yet", f.Name, height)
+	}
+	return f.solved[idx], nil
+}
+
+// solveNext builds and solves the next scripted block for this fork,
+// chaining it off the fork's current tip rather than ctx.Chain.BestBlock.
+// blk.Timestamp/blk.Bits override the scripted clock/target when set.
+func (f *ctFork) solveNext(ctx *ChainTestContext, blk *ctBlock) (*types.Block, error) {
+	parent, err := f.parentBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bits := blk.Bits
+	if bits == 0 {
+		bits = f.Bits
+	}
+	if bits == 0 {
+		bits = parent.Bits
+	}
+
+	timestamp := blk.Timestamp
+	if timestamp == 0 {
+		timestamp = ctx.Clock.Advance(defaultDuration)
+	}
+
+	block, err := blk.createForkBlock(ctx, f, parent.Height+1, timestamp, parent.Hash(), bits)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Solver.Solve(ctx, block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// catchUp keeps mining empty blocks on f (advancing ctx.Clock each time)
+// until it out-weighs ctx.Chain's current tip and Chain.ProcessBlock
+// switches the main chain over, or reorganizeCatchUpCap is exceeded.
+func (f *ctFork) catchUp(ctx *ChainTestContext) error {
+	for i := 0; i < reorganizeCatchUpCap; i++ {
+		tip, err := f.parentBlock(ctx)
+		if err != nil {
+			return err
+		}
+		if ctx.Chain.InMainChain(tip.Height, tip.Hash()) {
+			return nil
+		}
+
+		bits := f.Bits
+		if bits == 0 {
+			bits = tip.Bits
+		}
+		timestamp := ctx.Clock.Advance(defaultDuration)
+		block, err := DefaultEmptyBlock(tip.Height+1, timestamp, tip.Hash(), bits)
+		if err != nil {
+			return err
+		}
+		if err := ctx.Solver.Solve(ctx, block); err != nil {
+			return err
+		}
+		f.solved = append(f.solved, block)
+	}
+	return fmt.Errorf("fork %q failed to out-weigh the main chain within %d catch-up blocks", f.Name, reorganizeCatchUpCap)
+}
+
+// createForkBlock is createBlock's fork-aware counterpart: it manipulates
+// prevBlockHash/bits directly via DefaultEmptyBlock instead of assuming
+// ctx.Chain.BestBlock is the parent, so it can build on a branch that
+// hasn't (or hasn't yet) become the main chain. DefaultEmptyBlock's roots
+// are only correct for its own coinbase-only tx set, so once any scripted
+// transactions are appended the commitments are rebuilt to match, the same
+// bookkeeping NewBlock does for the canonical path when handed a full tx
+// list up front.
+func (b *ctBlock) createForkBlock(ctx *ChainTestContext, f *ctFork, height, timestamp uint64, prevBlockHash bc.Hash, bits uint64) (*types.Block, error) {
+	block, err := DefaultEmptyBlock(height, timestamp, prevBlockHash, bits)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.Transactions) == 0 {
+		return block, nil
+	}
+
+	txs := make([]*types.Tx, 0, len(b.Transactions))
+	for _, t := range b.Transactions {
+		tx, err := t.createForkTransaction(ctx, f, txs)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	block.Transactions = append(block.Transactions, txs...)
+
+	if err := recomputeBlockCommitments(block); err != nil {
+		return nil, err
+	}
+	return block, nil
+}
+
+// recomputeBlockCommitments rebuilds TransactionsMerkleRoot and
+// TransactionStatusHash from block.Transactions. createForkBlock needs
+// this because it appends scripted transactions onto a header
+// DefaultEmptyBlock built for zero of them; left alone, the stale roots
+// would fail validateStatus's merkle check (and real ProcessBlock
+// validation) the moment a fork carries anything beyond its coinbase.
+func recomputeBlockCommitments(block *types.Block) error {
+	bcTxs := make([]*bc.Tx, 0, len(block.Transactions))
+	statuses := make([]*bc.TxVerifyResult, 0, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		bcTxs = append(bcTxs, tx.Tx)
+		statuses = append(statuses, &bc.TxVerifyResult{StatusFail: false})
+	}
+
+	merkleRoot, err := bc.TxMerkleRoot(bcTxs)
+	if err != nil {
+		return err
+	}
+	statusRoot, err := bc.TxStatusMerkleRoot(statuses)
+	if err != nil {
+		return err
+	}
+
+	block.TransactionsMerkleRoot = merkleRoot
+	block.TransactionStatusHash = statusRoot
+	return nil
 }
 
 type ctTransaction struct {
@@ -203,6 +493,26 @@ func (input *ctInput) createDependencyTxInput(txs []*types.Tx) (*types.TxInput,
 	}, nil
 }
 
+// createForkTxInput resolves a fork transaction's input against the
+// fork's own branch, so it can spend outputs created above the fork point
+// that ctx.Chain.GetBlockByHeight would never see while the fork trails.
+func (input *ctInput) createForkTxInput(ctx *ChainTestContext, f *ctFork) (*types.TxInput, error) {
+	block, err := f.blockAt(ctx, input.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	spendInput, err := CreateSpendInput(block.Transactions[input.TxIndex], input.OutputIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TxInput{
+		AssetVersion: assetVersion,
+		TypedInput:   spendInput,
+	}, nil
+}
+
 func (t *ctTransaction) createTransaction(ctx *ChainTestContext, txs []*types.Tx) (*types.Tx, error) {
 	builder := txbuilder.NewBuilder(time.Now())
 	sigInst := &txbuilder.SigningInstruction{}
@@ -230,13 +540,54 @@ func (t *ctTransaction) createTransaction(ctx *ChainTestContext, txs []*types.Tx
 	return tpl.Transaction, err
 }
 
+// createForkTransaction is createTransaction's fork-aware counterpart,
+// resolving inputs against the fork's own branch instead of ctx.Chain.
+func (t *ctTransaction) createForkTransaction(ctx *ChainTestContext, f *ctFork, txs []*types.Tx) (*types.Tx, error) {
+	builder := txbuilder.NewBuilder(time.Now())
+	sigInst := &txbuilder.SigningInstruction{}
+	currentHeight := f.ParentHeight + uint64(len(f.solved))
+	for _, input := range t.Inputs {
+		var txInput *types.TxInput
+		var err error
+		if input.Height == currentHeight+1 {
+			txInput, err = input.createDependencyTxInput(txs)
+		} else {
+			txInput, err = input.createForkTxInput(ctx, f)
+		}
+		if err != nil {
+			return nil, err
+		}
+		builder.AddInput(txInput, sigInst)
+	}
+
+	for _, amount := range t.Outputs {
+		output := types.NewTxOutput(*consensus.BTMAssetID, amount, []byte{byte(vm.OP_TRUE)})
+		builder.AddOutput(output)
+	}
+
+	tpl, _, err := builder.Build()
+	return tpl.Transaction, err
+}
+
 func (cfg *ChainTestConfig) Run() error {
 	db := dbm.NewDB("chain_test_db", "leveldb", "chain_test_db")
 	defer os.RemoveAll("chain_test_db")
 	chain, _ := MockChain(db)
+	var solver Solver = powSolver{}
+	if cfg.MockDifficulty != 0 {
+		solver = mockSolver{bits: cfg.MockDifficulty}
+	}
 	ctx := &ChainTestContext{
-		Chain: chain,
-		DB:    db,
+		Chain:  chain,
+		DB:     db,
+		Cache:  leveldb.NewCachedUtxoStore(db, defaultCachePersistInterval, defaultCacheFlushThreshold),
+		Clock:  NewTestClock(chain.BestBlock().Timestamp),
+		Solver: solver,
+	}
+
+	flushAt := make(map[uint64]bool, len(cfg.CacheFlushAt))
+	for _, h := range cfg.CacheFlushAt {
+		flushAt[h] = true
 	}
 
 	var utxoEntries map[string]*storage.UtxoEntry
@@ -246,9 +597,17 @@ func (cfg *ChainTestConfig) Run() error {
 		if err != nil {
 			return err
 		}
-		if err := SolveAndUpdate(ctx.Chain, block); err != nil {
+		if err := ctx.Solver.Solve(ctx, block); err != nil {
+			return err
+		}
+		if err := ctx.applyBlockToCache(block); err != nil {
 			return err
 		}
+		if flushAt[block.Height] {
+			if err := ctx.Cache.Persist(); err != nil {
+				return err
+			}
+		}
 		if err := ctx.validateStatus(block); err != nil {
 			return err
 		}
@@ -264,6 +623,13 @@ func (cfg *ChainTestConfig) Run() error {
 		}
 	}
 
+	if len(cfg.Forks) > 0 {
+		canonicalTip := ctx.Chain.BestBlock()
+		if err := ctx.runForks(cfg, canonicalTip); err != nil {
+			return err
+		}
+	}
+
 	if rollbackBlock == nil {
 		return nil
 	}
@@ -272,6 +638,9 @@ func (cfg *ChainTestConfig) Run() error {
 	if err := ctx.Chain.ReorganizeChain(rollbackBlock); err != nil {
 		return err
 	}
+	// the rolled-back blocks' UTXO mutations may still be sitting in the
+	// cache uncommitted; drop them rather than flushing discarded state.
+	ctx.Cache.Discard()
 	if err := ctx.validateRollback(utxoEntries); err != nil {
 		return err
 	}
@@ -281,6 +650,137 @@ func (cfg *ChainTestConfig) Run() error {
 	return nil
 }
 
+// runForks feeds every fork's blocks to SolveAndUpdate in the order named
+// by cfg.ForkOrder, then checks that chain selection ended up favoring
+// whichever branch (canonical or fork) cfg.WantMainChain names.
+func (ctx *ChainTestContext) runForks(cfg *ChainTestConfig, canonicalTip *types.Block) error {
+	forks := make(map[string]*ctFork, len(cfg.Forks))
+	for _, f := range cfg.Forks {
+		forks[f.Name] = f
+	}
+
+	cursor := make(map[string]int, len(cfg.Forks))
+	for _, name := range cfg.ForkOrder {
+		f, ok := forks[name]
+		if !ok {
+			return fmt.Errorf("fork_order references unknown fork %q", name)
+		}
+
+		idx := cursor[name]
+		if idx >= len(f.Blocks) {
+			return fmt.Errorf("fork %q has no more blocks to feed", name)
+		}
+
+		blk := f.Blocks[idx]
+		block, err := f.solveNext(ctx, blk)
+		if err != nil {
+			return err
+		}
+
+		f.solved = append(f.solved, block)
+		cursor[name]++
+
+		if blk.Reorganize {
+			if err := f.catchUp(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return ctx.validateForks(cfg, canonicalTip)
+}
+
+// validateForks asserts the chain-organization outcome of a fork scenario:
+// the expected branch is the main chain and holds ctx.Chain.BestBlock, every
+// losing fork's UTXOs are gone while the winner's remain, and the winning
+// tip reports the right transaction status. The UTXOs of a displaced
+// canonical branch and the transaction status of losing tips are not
+// checked.
+func (ctx *ChainTestContext) validateForks(cfg *ChainTestConfig, canonicalTip *types.Block) error {
+	forks := make(map[string]*ctFork, len(cfg.Forks))
+	for _, f := range cfg.Forks {
+		forks[f.Name] = f
+	}
+
+	want := canonicalTip
+	if cfg.WantMainChain != "" {
+		f, ok := forks[cfg.WantMainChain]
+		if !ok || len(f.solved) == 0 {
+			return fmt.Errorf("want_main_chain %q never produced a block", cfg.WantMainChain)
+		}
+		want = f.solved[len(f.solved)-1]
+	}
+	if err := ctx.validateStatus(want); err != nil {
+		return err
+	}
+
+	if canonicalTip != nil {
+		isWinner := cfg.WantMainChain == ""
+		// a canonical tip at or below the winning fork's parent height is an
+		// ancestor of that fork, so it stays in the main chain either way
+		if !isWinner && canonicalTip.Height <= forks[cfg.WantMainChain].ParentHeight {
+			isWinner = true
+		}
+		inMain := ctx.Chain.InMainChain(canonicalTip.Height, canonicalTip.Hash())
+		if isWinner && !inMain {
+			return fmt.Errorf("canonical chain tip at height %d should still be in main chain", canonicalTip.Height)
+		}
+		if !isWinner && inMain {
+			return fmt.Errorf("canonical chain tip at height %d should have been displaced", canonicalTip.Height)
+		}
+	}
+
+	for name, f := range forks {
+		isWinner := name == cfg.WantMainChain
+		for i, block := range f.solved {
+			inMain := ctx.Chain.InMainChain(block.Height, block.Hash())
+			if isWinner && !inMain {
+				return fmt.Errorf("fork %q block at height %d should be in main chain", name, block.Height)
+			}
+			if !isWinner && inMain {
+				return fmt.Errorf("fork %q block at height %d should not be in main chain", name, block.Height)
+			}
+			if err := ctx.validateForkUtxos(block, f.solved[i+1:], isWinner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateForkUtxos checks that a losing branch's outputs never stuck
+// around as spendable UTXOs, while a winning branch's outputs are present
+// and unspent unless consumed later in the same branch. later holds the
+// branch's blocks that follow block.
+func (ctx *ChainTestContext) validateForkUtxos(block *types.Block, later []*types.Block, isWinner bool) error {
+	for _, tx := range block.Transactions {
+		for _, outputID := range tx.ResultIds {
+			utxoEntry, _ := leveldb.GetUtxo(ctx.DB, outputID)
+			if !isWinner {
+				if utxoEntry != nil && !isSpent(outputID, block) {
+					return fmt.Errorf("losing branch utxo at height %d should have been discarded", block.Height)
+				}
+				continue
+			}
+
+			consumed := isSpent(outputID, block)
+			for _, laterBlock := range later {
+				consumed = consumed || isSpent(outputID, laterBlock)
+			}
+			if utxoEntry == nil {
+				if consumed {
+					continue
+				}
+				return fmt.Errorf("winning branch utxo at height %d should be present", block.Height)
+			}
+			if utxoEntry.Spent && !consumed {
+				return fmt.Errorf("winning branch utxo at height %d should be unspent", block.Height)
+			}
+		}
+	}
+	return nil
+}
+
 // if the output(hash) was spent in block
 func isSpent(hash *bc.Hash, block *types.Block) bool {
 	for _, tx := range block.Transactions {
@@ -0,0 +1,32 @@
+package test
+
+import "sync"
+
+// TestClock is a monotonically-advanceable clock scripted scenarios use in
+// place of wall-clock time, so hundreds of blocks can be timestamped and
+// mined without actually waiting seconds apart.
+type TestClock struct {
+	mu  sync.Mutex
+	now uint64
+}
+
+// NewTestClock starts the clock at start, typically a chain's genesis
+// block timestamp.
+func NewTestClock(start uint64) *TestClock {
+	return &TestClock{now: start}
+}
+
+// Now returns the clock's current timestamp.
+func (c *TestClock) Now() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d and returns the new timestamp.
+func (c *TestClock) Advance(d uint64) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now += d
+	return c.now
+}
@@ -0,0 +1,40 @@
+package test
+
+import "testing"
+
+// TestForkReorg scripts two branches off the same parent height: "short"
+// gets a single block, "long" gets two, so once both are fed "long" has
+// more accumulated work and should become the main chain. This exercises
+// Forks/ForkOrder/WantMainChain end to end - including the heaviest-chain
+// assertions in validateForks - rather than leaving the fork machinery
+// untested.
+func TestForkReorg(t *testing.T) {
+	cfg := &ChainTestConfig{
+		Blocks: []*ctBlock{
+			{},
+		},
+		Forks: []*ctFork{
+			{
+				Name:         "short",
+				ParentHeight: 1,
+				Blocks: []*ctBlock{
+					{},
+				},
+			},
+			{
+				Name:         "long",
+				ParentHeight: 1,
+				Blocks: []*ctBlock{
+					{},
+					{},
+				},
+			},
+		},
+		ForkOrder:     []string{"short", "long", "long"},
+		WantMainChain: "long",
+	}
+
+	if err := cfg.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,39 @@
+package test
+
+import "testing"
+
+// testEasyBits is a deliberately low-difficulty target, the same kind of
+// eased bits regtest-style chains use so real proof-of-work still runs but
+// finds a nonce almost immediately.
+const testEasyBits = 0x207fffff
+
+// TestMockDifficultyReorg scripts a canonical chain that gets ahead, then a
+// lagging fork whose last block is marked Reorganize: true. That should
+// drive ctFork.catchUp to keep mining under the mocked difficulty,
+// advancing TestClock each time, until the fork out-weighs the canonical
+// tip and Chain.ProcessBlock switches the main chain over to it. This
+// exercises MockDifficulty, TestClock and Reorganize end to end, rather
+// than leaving them untested.
+func TestMockDifficultyReorg(t *testing.T) {
+	cfg := &ChainTestConfig{
+		MockDifficulty: testEasyBits,
+		Blocks: []*ctBlock{
+			{Append: 2},
+		},
+		Forks: []*ctFork{
+			{
+				Name:         "challenger",
+				ParentHeight: 1,
+				Blocks: []*ctBlock{
+					{Reorganize: true},
+				},
+			},
+		},
+		ForkOrder:     []string{"challenger"},
+		WantMainChain: "challenger",
+	}
+
+	if err := cfg.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,33 @@
+package test
+
+import "github.com/bytom/protocol/bc/types"
+
+// Solver solves block's proof-of-work and submits it to ctx.Chain, the way
+// SolveAndUpdate always has. It's pluggable so a scenario that needs
+// hundreds of blocks (difficulty-adjustment or long-reorg tests) can swap
+// in a cheaper stand-in instead of mining against the real target.
+type Solver interface {
+	Solve(ctx *ChainTestContext, block *types.Block) error
+}
+
+// powSolver is the harness's original behavior: real proof-of-work against
+// block.Bits as set by the scenario.
+type powSolver struct{}
+
+func (powSolver) Solve(ctx *ChainTestContext, block *types.Block) error {
+	return SolveAndUpdate(ctx.Chain, block)
+}
+
+// mockSolver eases block.Bits to a near-trivial target before solving, so
+// proof-of-work is still real but finds a nonce almost immediately. This
+// is what lets ChainTestConfig.MockDifficulty generate long chains cheaply.
+type mockSolver struct {
+	bits uint64
+}
+
+func (s mockSolver) Solve(ctx *ChainTestContext, block *types.Block) error {
+	if s.bits != 0 {
+		block.Bits = s.bits
+	}
+	return SolveAndUpdate(ctx.Chain, block)
+}